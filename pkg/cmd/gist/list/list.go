@@ -0,0 +1,53 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cli/cli/internal/ghinstance"
+	"github.com/cli/cli/pkg/cmd/gist/shared"
+)
+
+// ListGists lists the authenticated user's gists a page at a time: page is
+// 1-indexed and perPage bounds how many gists that page contains, so
+// callers that only need the next handful of results (e.g. a lazily
+// paginated browser) don't have to re-fetch everything seen so far with a
+// growing limit. visibility filters the result to "public", "secret", or
+// "all".
+func ListGists(client *http.Client, hostname string, page, perPage int, visibility string) ([]shared.Gist, error) {
+	url := fmt.Sprintf("%sgists?page=%d&per_page=%d", ghinstance.RESTPrefix(hostname), page, perPage)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("listing gists failed: %s", resp.Status)
+	}
+
+	var gists []shared.Gist
+	if err := json.NewDecoder(resp.Body).Decode(&gists); err != nil {
+		return nil, err
+	}
+
+	if visibility == "all" || visibility == "" {
+		return gists, nil
+	}
+
+	filtered := gists[:0]
+	for _, g := range gists {
+		if (visibility == "public") == g.Public {
+			filtered = append(filtered, g)
+		}
+	}
+	return filtered, nil
+}
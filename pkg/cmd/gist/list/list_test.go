@@ -0,0 +1,64 @@
+package list
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func stringResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{},
+	}
+}
+
+func TestListGists_requestsThePage(t *testing.T) {
+	var gotURL string
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotURL = req.URL.String()
+		return stringResponse(200, `[{"id":"1","public":true},{"id":"2","public":false}]`), nil
+	})}
+
+	gists, err := ListGists(client, "github.com", 2, 30, "all")
+	require.NoError(t, err)
+	assert.Len(t, gists, 2)
+	assert.Contains(t, gotURL, "page=2")
+	assert.Contains(t, gotURL, "per_page=30")
+}
+
+func TestListGists_filtersByVisibility(t *testing.T) {
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return stringResponse(200, `[{"id":"1","public":true},{"id":"2","public":false}]`), nil
+	})}
+
+	gists, err := ListGists(client, "github.com", 1, 30, "public")
+	require.NoError(t, err)
+	require.Len(t, gists, 1)
+	assert.Equal(t, "1", gists[0].ID)
+
+	gists, err = ListGists(client, "github.com", 1, 30, "secret")
+	require.NoError(t, err)
+	require.Len(t, gists, 1)
+	assert.Equal(t, "2", gists[0].ID)
+}
+
+func TestListGists_errorResponse(t *testing.T) {
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return stringResponse(500, `{"message":"boom"}`), nil
+	})}
+
+	_, err := ListGists(client, "github.com", 1, 30, "all")
+	assert.Error(t, err)
+}
@@ -0,0 +1,305 @@
+package view
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cli/cli/internal/config"
+	"github.com/cli/cli/internal/ghinstance"
+	"github.com/cli/cli/pkg/cmd/gist/shared"
+)
+
+// cacheMaxAge bounds how long an entry is kept for offline fallback before
+// eviction. cacheMaxBytes bounds the total on-disk size of the gist cache;
+// once exceeded, the oldest entries are evicted first.
+const (
+	cacheMaxAge   = 24 * time.Hour
+	cacheMaxBytes = 50 * 1024 * 1024
+)
+
+// gistCacheEntry lives on disk keyed by gist ID (see gistCachePath) and is
+// revalidated against the API using ETag, which is derived from the
+// gist's updated_at so an entry is only ever current for the exact
+// version of the gist it was written from.
+type gistCacheEntry struct {
+	Gist     *shared.Gist `json:"gist"`
+	ETag     string       `json:"etag"`
+	CachedAt time.Time    `json:"cached_at"`
+}
+
+// getGist reads the on-disk cache first, then revalidates it with a
+// single GraphQL request carrying If-None-Match: a 304 means the cached
+// gist is still current and is returned without re-fetching any file
+// content, which is what makes repeated `gh gist view` calls against the
+// same gist fast. Any other response always wins over the cache and is
+// written back for next time. If the request itself fails (e.g. no
+// network) the cache is served as a stale fallback rather than erroring.
+// --no-cache disables both reading and writing the cache.
+func getGist(opts *ViewOptions, client *http.Client, host, gistID string) (*shared.Gist, error) {
+	if opts.NoCache {
+		gist, _, err := fetchGist(client, host, gistID, "")
+		return gist, err
+	}
+
+	entry, _ := readGistCache(gistID)
+	etag := ""
+	if entry != nil {
+		etag = entry.ETag
+	}
+
+	gist, newETag, err := fetchGist(client, host, gistID, etag)
+	if err != nil {
+		if entry != nil {
+			return entry.Gist, nil
+		}
+		return nil, err
+	}
+
+	if gist == nil {
+		// 304 Not Modified: the cache entry is still current.
+		return entry.Gist, nil
+	}
+
+	_ = writeGistCache(gistID, gist, newETag)
+	return gist, nil
+}
+
+// fetchGist fetches gistID via GraphQL, falling back to the REST API
+// (which has no ETag revalidation or file-content batching, but is
+// available everywhere) when GraphQL itself is unavailable -- e.g. a GHES
+// instance older than the release that added this schema, or the query
+// being rejected outright. It returns a nil gist and no error on a 304,
+// signalling that the caller's cached copy is still current.
+func fetchGist(client *http.Client, host, gistID, etag string) (*shared.Gist, string, error) {
+	gist, newETag, err := graphQLFetchGist(client, host, gistID, etag)
+	if err == nil {
+		return gist, newETag, nil
+	}
+
+	restGist, restErr := shared.GetGist(client, host, gistID)
+	if restErr != nil {
+		return nil, "", err
+	}
+	return restGist, "", nil
+}
+
+// gistQueryFileLimit caps how many files GistView requests per gist in one
+// round trip; the schema defaults Gist.files(limit:) to 10, which would
+// silently drop files beyond that for any gist with more than 10 files.
+const gistQueryFileLimit = 300
+
+// gistQuery fetches a gist's metadata and every file's content in a single
+// round trip, batching what the REST API would otherwise split into a
+// gist fetch plus a body per file.
+var gistQuery = fmt.Sprintf(`query GistView($id: ID!) {
+	gist(id: $id) {
+		id
+		description
+		isPublic
+		updatedAt
+		owner { login }
+		files(limit: %d) {
+			name
+			type
+			language { name }
+			text
+		}
+	}
+}`, gistQueryFileLimit)
+
+type gistQueryFile struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Language struct {
+		Name string `json:"name"`
+	} `json:"language"`
+	Text string `json:"text"`
+}
+
+type gistQueryResponse struct {
+	Data struct {
+		Gist *struct {
+			ID          string    `json:"id"`
+			Description string    `json:"description"`
+			Public      bool      `json:"isPublic"`
+			UpdatedAt   time.Time `json:"updatedAt"`
+			Owner       struct {
+				Login string `json:"login"`
+			} `json:"owner"`
+			Files []gistQueryFile `json:"files"`
+		} `json:"gist"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// graphQLFetchGist fetches gistID via the GraphQL API, sending etag as
+// If-None-Match when non-empty. It returns a nil gist and no error on a
+// 304 response, signalling that the caller's cached copy is still
+// current. The returned etag is derived from the gist's updated_at, since
+// that's what actually changes when a gist is edited.
+func graphQLFetchGist(client *http.Client, host, gistID, etag string) (*shared.Gist, string, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     gistQuery,
+		"variables": map[string]string{"id": gistID},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequest("POST", ghinstance.GraphQLEndpoint(host), bytes.NewReader(body))
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, etag, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", fmt.Errorf("GraphQL request failed: %s", resp.Status)
+	}
+
+	var gr gistQueryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&gr); err != nil {
+		return nil, "", err
+	}
+	if len(gr.Errors) > 0 {
+		return nil, "", fmt.Errorf("GraphQL: %s", gr.Errors[0].Message)
+	}
+	if gr.Data.Gist == nil {
+		return nil, "", fmt.Errorf("gist not found")
+	}
+
+	g := gr.Data.Gist
+	gist := &shared.Gist{
+		ID:          g.ID,
+		Description: g.Description,
+		Public:      g.Public,
+		UpdatedAt:   g.UpdatedAt,
+		Owner:       &shared.GistOwner{Login: g.Owner.Login},
+		Files:       make(map[string]*shared.GistFile, len(g.Files)),
+	}
+	for _, f := range g.Files {
+		gist.Files[f.Name] = &shared.GistFile{
+			Filename: f.Name,
+			Type:     f.Type,
+			Language: f.Language.Name,
+			Content:  f.Text,
+		}
+	}
+
+	return gist, fmt.Sprintf("%q", g.UpdatedAt.UTC().Format(time.RFC3339Nano)), nil
+}
+
+func gistCacheDir() (string, error) {
+	dir := filepath.Join(config.ConfigDir(), "gist-cache")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+func gistCachePath(gistID string) (string, error) {
+	dir, err := gistCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, gistID+".json"), nil
+}
+
+func readGistCache(gistID string) (*gistCacheEntry, error) {
+	path, err := gistCachePath(gistID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entry gistCacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil {
+		// A corrupt cache entry is treated as a miss rather than an error.
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+func writeGistCache(gistID string, gist *shared.Gist, etag string) error {
+	path, err := gistCachePath(gistID)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(gistCacheEntry{Gist: gist, ETag: etag, CachedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, b, 0600); err != nil {
+		return err
+	}
+	return evictStaleGistCacheEntries()
+}
+
+// evictStaleGistCacheEntries removes entries older than cacheMaxAge, then
+// trims the oldest remaining entries until the cache fits cacheMaxBytes.
+func evictStaleGistCacheEntries() error {
+	dir, err := gistCacheDir()
+	if err != nil {
+		return err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	kept := files[:0]
+	for _, fi := range files {
+		if time.Since(fi.ModTime()) > cacheMaxAge {
+			_ = os.Remove(filepath.Join(dir, fi.Name()))
+			continue
+		}
+		total += fi.Size()
+		kept = append(kept, fi)
+	}
+
+	if total <= cacheMaxBytes {
+		return nil
+	}
+
+	sort.Slice(kept, func(i, j int) bool {
+		return kept[i].ModTime().Before(kept[j].ModTime())
+	})
+	for _, fi := range kept {
+		if total <= cacheMaxBytes {
+			break
+		}
+		if err := os.Remove(filepath.Join(dir, fi.Name())); err != nil {
+			continue
+		}
+		total -= fi.Size()
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package view
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/cli/pkg/cmd/gist/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func stringResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{},
+	}
+}
+
+func TestGetGist_cachesThenRevalidatesWithETag(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	var gotIfNoneMatch []string
+	calls := 0
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		gotIfNoneMatch = append(gotIfNoneMatch, req.Header.Get("If-None-Match"))
+		if calls == 1 {
+			return stringResponse(200, `{"data":{"gist":{"id":"abc123","description":"demo","isPublic":true,"updatedAt":"2024-01-01T00:00:00Z","owner":{"login":"monalisa"},"files":[{"name":"a.txt","type":"text/plain","language":{"name":"Text"},"text":"hi\n"}]}}}`), nil
+		}
+		return stringResponse(http.StatusNotModified, ""), nil
+	})}
+
+	opts := &ViewOptions{}
+
+	gist, err := getGist(opts, client, "github.com", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "hi\n", gist.Files["a.txt"].Content)
+	assert.Equal(t, "Text", gist.Files["a.txt"].Language)
+	assert.Equal(t, "", gotIfNoneMatch[0])
+
+	gist, err = getGist(opts, client, "github.com", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "hi\n", gist.Files["a.txt"].Content)
+	assert.Equal(t, 2, calls)
+	assert.NotEmpty(t, gotIfNoneMatch[1])
+}
+
+func TestGraphQLFetchGist_requestBody(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	var gotBody map[string]interface{}
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(b, &gotBody))
+		return stringResponse(200, `{"data":{"gist":{"id":"abc123","files":[]}}}`), nil
+	})}
+
+	_, _, err := graphQLFetchGist(client, "github.com", "abc123", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"id": "abc123"}, gotBody["variables"])
+
+	query, ok := gotBody["query"].(string)
+	require.True(t, ok)
+	assert.Contains(t, query, "GistView($id: ID!)")
+	assert.Contains(t, query, fmt.Sprintf("files(limit: %d)", gistQueryFileLimit))
+	assert.Contains(t, query, "language { name }")
+}
+
+func TestGetGist_fallsBackToRESTWhenGraphQLUnavailable(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == "POST" {
+			return stringResponse(404, `{"message":"Not Found"}`), nil
+		}
+		return stringResponse(200, `{"id":"abc123","description":"demo","public":true,"owner":{"login":"monalisa"},"updated_at":"2024-01-01T00:00:00Z","files":{"a.txt":{"filename":"a.txt","type":"text/plain","content":"rest\n"}}}`), nil
+	})}
+
+	gist, err := getGist(&ViewOptions{}, client, "github.com", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "rest\n", gist.Files["a.txt"].Content)
+}
+
+func TestGetGist_fallsBackToCacheOnError(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	cached := &shared.Gist{ID: "abc123", Files: map[string]*shared.GistFile{"a.txt": {Content: "cached\n"}}}
+	require.NoError(t, writeGistCache("abc123", cached, `"etag-1"`))
+
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, assert.AnError
+	})}
+
+	gist, err := getGist(&ViewOptions{}, client, "github.com", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "cached\n", gist.Files["a.txt"].Content)
+}
+
+func TestGetGist_noCacheBypassesDisk(t *testing.T) {
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+
+	calls := 0
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		assert.Empty(t, req.Header.Get("If-None-Match"))
+		return stringResponse(200, `{"data":{"gist":{"id":"abc123","files":[]}}}`), nil
+	})}
+
+	_, err := getGist(&ViewOptions{NoCache: true}, client, "github.com", "abc123")
+	require.NoError(t, err)
+	_, err = getGist(&ViewOptions{NoCache: true}, client, "github.com", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	entry, err := readGistCache("abc123")
+	require.NoError(t, err)
+	assert.Nil(t, entry)
+}
+
+func TestEvictStaleGistCacheEntries(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GH_CONFIG_DIR", dir)
+
+	cacheDir, err := gistCacheDir()
+	require.NoError(t, err)
+
+	old := filepath.Join(cacheDir, "old.json")
+	require.NoError(t, ioutil.WriteFile(old, []byte(`{}`), 0600))
+	require.NoError(t, os.Chtimes(old, time.Now().Add(-48*time.Hour), time.Now().Add(-48*time.Hour)))
+
+	fresh := filepath.Join(cacheDir, "fresh.json")
+	require.NoError(t, ioutil.WriteFile(fresh, []byte(`{}`), 0600))
+
+	require.NoError(t, evictStaleGistCacheEntries())
+
+	_, err = os.Stat(old)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(fresh)
+	assert.NoError(t, err)
+}
+
+func TestEvictStaleGistCacheEntries_trimsOversizedCache(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GH_CONFIG_DIR", dir)
+
+	cacheDir, err := gistCacheDir()
+	require.NoError(t, err)
+
+	big := make([]byte, cacheMaxBytes)
+	oldest := filepath.Join(cacheDir, "oldest.json")
+	require.NoError(t, ioutil.WriteFile(oldest, big, 0600))
+	require.NoError(t, os.Chtimes(oldest, time.Now().Add(-time.Hour), time.Now().Add(-time.Hour)))
+
+	newest := filepath.Join(cacheDir, "newest.json")
+	require.NoError(t, ioutil.WriteFile(newest, []byte(`{}`), 0600))
+
+	require.NoError(t, evictStaleGistCacheEntries())
+
+	_, err = os.Stat(oldest)
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(newest)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,55 @@
+package view
+
+import "github.com/cli/cli/pkg/cmd/gist/shared"
+
+// gistFileTruncationThreshold mirrors GitHub's documented limit for gist
+// file content returned in full by the REST API; content at or beyond it
+// may have been truncated server-side.
+const gistFileTruncationThreshold = 1000000
+
+// exportableGist adapts shared.Gist to cmdutil's ExportData interface so
+// `--json` can report per-file size and truncation state that shared.Gist
+// itself doesn't carry.
+type exportableGist struct {
+	gist *shared.Gist
+}
+
+func (e *exportableGist) ExportData(fields []string) map[string]interface{} {
+	out := map[string]interface{}{}
+	for _, f := range fields {
+		switch f {
+		case "id":
+			out["id"] = e.gist.ID
+		case "description":
+			out["description"] = e.gist.Description
+		case "public":
+			out["public"] = e.gist.Public
+		case "owner":
+			out["owner"] = e.gist.Owner
+		case "updated_at":
+			out["updated_at"] = e.gist.UpdatedAt
+		case "files":
+			out["files"] = e.exportFiles()
+		}
+	}
+	return out
+}
+
+func (e *exportableGist) exportFiles() []map[string]interface{} {
+	filenames := sortedFilenames(e.gist)
+	files := make([]map[string]interface{}, 0, len(filenames))
+	for _, fn := range filenames {
+		gf := e.gist.Files[fn]
+		files = append(files, map[string]interface{}{
+			"filename": fn,
+			"language": gf.Language,
+			"type":     gf.Type,
+			"size":     len(gf.Content),
+			"content":  gf.Content,
+			// shared.GetGist doesn't retain the REST API's own truncated
+			// flag, so this falls back to a size-based heuristic.
+			"truncated": len(gf.Content) >= gistFileTruncationThreshold,
+		})
+	}
+	return files
+}
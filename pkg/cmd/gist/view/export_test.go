@@ -0,0 +1,56 @@
+package view
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cli/cli/pkg/cmd/gist/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportableGist_ExportData(t *testing.T) {
+	gist := &shared.Gist{
+		ID:          "abc123",
+		Description: "a test gist",
+		Public:      true,
+		Owner:       &shared.GistOwner{Login: "monalisa"},
+		Files: map[string]*shared.GistFile{
+			"small.go": {Filename: "small.go", Type: "text/plain", Language: "Go", Content: "package main\n"},
+			"big.txt":  {Filename: "big.txt", Type: "text/plain", Content: strings.Repeat("a", gistFileTruncationThreshold)},
+		},
+	}
+
+	e := &exportableGist{gist: gist}
+	data := e.ExportData([]string{"id", "description", "public", "owner", "files"})
+
+	assert.Equal(t, "abc123", data["id"])
+	assert.Equal(t, "a test gist", data["description"])
+	assert.Equal(t, true, data["public"])
+	assert.Equal(t, gist.Owner, data["owner"])
+
+	files, ok := data["files"].([]map[string]interface{})
+	assert.True(t, ok)
+	assert.Len(t, files, 2)
+
+	byName := map[string]map[string]interface{}{}
+	for _, f := range files {
+		byName[f["filename"].(string)] = f
+	}
+
+	small := byName["small.go"]
+	assert.Equal(t, "Go", small["language"])
+	assert.Equal(t, len("package main\n"), small["size"])
+	assert.Equal(t, false, small["truncated"])
+
+	big := byName["big.txt"]
+	assert.Equal(t, true, big["truncated"])
+}
+
+func TestExportableGist_ExportData_onlyRequestedFields(t *testing.T) {
+	gist := &shared.Gist{ID: "abc123", Files: map[string]*shared.GistFile{}}
+	e := &exportableGist{gist: gist}
+
+	data := e.ExportData([]string{"id"})
+
+	assert.Equal(t, map[string]interface{}{"id": "abc123"}, data)
+}
@@ -0,0 +1,53 @@
+package view
+
+import (
+	"io"
+	"path/filepath"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/formatters"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/cli/cli/pkg/cmd/gist/shared"
+)
+
+// highlightSource writes a syntax-highlighted rendering of gf to w and
+// reports whether highlighting was applied. It returns false, nil when no
+// chroma lexer can be matched, so callers can fall back to raw output.
+func highlightSource(w io.Writer, filename string, gf *shared.GistFile, dark bool) (bool, error) {
+	lexer := lexers.Get(gf.Language)
+	if lexer == nil {
+		lexer = lexers.Match(filepath.Base(filename))
+	}
+	if lexer == nil {
+		lexer = lexers.Analyse(gf.Content)
+	}
+	if lexer == nil {
+		return false, nil
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	style := styles.Get(highlightStyleName(dark))
+	if style == nil {
+		style = styles.Fallback
+	}
+
+	iterator, err := lexer.Tokenise(nil, gf.Content)
+	if err != nil {
+		return false, err
+	}
+
+	if err := formatters.TTY256.Format(w, style, iterator); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// highlightStyleName picks a chroma style matched to the detected terminal
+// background, mirroring the light/dark split markdown.GetStyle makes.
+func highlightStyleName(dark bool) string {
+	if dark {
+		return "monokai"
+	}
+	return "github"
+}
@@ -0,0 +1,55 @@
+package view
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cli/cli/pkg/cmd/gist/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		gf       *shared.GistFile
+		wantOK   bool
+	}{
+		{
+			name:     "matches by language",
+			filename: "gistfile1.txt",
+			gf:       &shared.GistFile{Language: "Go", Content: "package main\n"},
+			wantOK:   true,
+		},
+		{
+			name:     "matches by filename extension",
+			filename: "main.go",
+			gf:       &shared.GistFile{Content: "package main\n"},
+			wantOK:   true,
+		},
+		{
+			name:     "no lexer match falls back to raw",
+			filename: "data.unknownext",
+			gf:       &shared.GistFile{Content: "\x00\x01\x02 not really code"},
+			wantOK:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ok, err := highlightSource(&buf, tt.filename, tt.gf, false)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.NotEmpty(t, buf.String())
+			}
+		})
+	}
+}
+
+func TestHighlightStyleName(t *testing.T) {
+	assert.Equal(t, "monokai", highlightStyleName(true))
+	assert.Equal(t, "github", highlightStyleName(false))
+}
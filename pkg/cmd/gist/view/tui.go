@@ -0,0 +1,330 @@
+package view
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/cli/cli/internal/ghinstance"
+	gistlist "github.com/cli/cli/pkg/cmd/gist/list"
+	"github.com/cli/cli/pkg/cmd/gist/shared"
+	"github.com/cli/cli/utils"
+)
+
+// browseKeyMap registers the browser's extra actions (beyond list.Model's
+// own navigation/filter keys) with key.Binding so they show up in the `?`
+// help screen via list.Model's AdditionalShortHelpKeys/AdditionalFullHelpKeys.
+var browseKeyMap = struct {
+	openBrowser key.Binding
+	copyURL     key.Binding
+	dumpStdout  key.Binding
+}{
+	openBrowser: key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "open in browser")),
+	copyURL:     key.NewBinding(key.WithKeys("y"), key.WithHelp("y", "copy URL")),
+	dumpStdout:  key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "print to stdout")),
+}
+
+// gistsPerPage is how many gists are requested from the API per
+// pagination step as the user scrolls to the bottom of the list.
+const gistsPerPage = 30
+
+// browseGists launches a full-screen gist browser and returns the ID of
+// the gist the user picked to view. handled is true, with an empty ID,
+// when the browser already performed the requested action (open in
+// browser, copy URL, dump raw content to stdout) or the user quit without
+// picking a gist, and viewRun has nothing left to do.
+func browseGists(client *http.Client, opts *ViewOptions) (gistID string, handled bool, err error) {
+	host := ghinstance.OverridableDefault()
+	m := newBrowseModel(client, host, opts)
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		return "", false, err
+	}
+
+	bm := final.(*browseModel)
+	if bm.err != nil {
+		return "", false, bm.err
+	}
+	if bm.selected == nil {
+		// User quit the browser without picking a gist; treat it like
+		// Ctrl-C on the survey prompt and exit quietly.
+		return "", true, nil
+	}
+
+	switch bm.action {
+	case actionOpenBrowser:
+		gistURL := ghinstance.GistPrefix(host) + bm.selected.ID
+		if opts.IO.IsStderrTTY() {
+			fmt.Fprintf(opts.IO.ErrOut, "Opening %s in your browser.\n", utils.DisplayURL(gistURL))
+		}
+		return "", true, utils.OpenInBrowser(gistURL)
+	case actionCopyURL:
+		gistURL := ghinstance.GistPrefix(host) + bm.selected.ID
+		if err := clipboard.WriteAll(gistURL); err != nil {
+			return "", false, err
+		}
+		fmt.Fprintf(opts.IO.ErrOut, "Copied %s to the clipboard.\n", utils.DisplayURL(gistURL))
+		return "", true, nil
+	case actionDumpStdout:
+		gist, err := getGist(opts, client, host, bm.selected.ID)
+		if err != nil {
+			return "", false, err
+		}
+		for _, fn := range sortedFilenames(gist) {
+			fmt.Fprint(opts.IO.Out, gist.Files[fn].Content)
+		}
+		return "", true, nil
+	default:
+		return bm.selected.ID, false, nil
+	}
+}
+
+type browseAction int
+
+const (
+	actionNone browseAction = iota
+	actionOpenBrowser
+	actionCopyURL
+	actionDumpStdout
+)
+
+type gistItem struct {
+	gist shared.Gist
+}
+
+func (i gistItem) Title() string {
+	if i.gist.Description != "" {
+		return i.gist.Description
+	}
+	return firstNonGenericFilename(&i.gist)
+}
+
+func (i gistItem) Description() string {
+	return fmt.Sprintf("%s · updated %s", i.gist.ID, utils.FuzzyAgo(time.Since(i.gist.UpdatedAt)))
+}
+
+func (i gistItem) FilterValue() string {
+	var names []string
+	for fn := range i.gist.Files {
+		names = append(names, fn)
+	}
+	return i.gist.Description + " " + strings.Join(names, " ")
+}
+
+type gistsLoadedMsg struct {
+	page  int
+	gists []shared.Gist
+	err   error
+}
+
+type previewLoadedMsg struct {
+	id      string
+	content string
+	err     error
+}
+
+type browseModel struct {
+	client *http.Client
+	host   string
+	opts   *ViewOptions
+
+	list    list.Model
+	preview viewport.Model
+
+	page     int
+	loadMore bool
+
+	previewFor   string
+	previewCache map[string]string
+
+	selected *shared.Gist
+	action   browseAction
+	err      error
+
+	ready bool
+}
+
+func newBrowseModel(client *http.Client, host string, opts *ViewOptions) *browseModel {
+	l := list.New(nil, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "Gists"
+	l.SetShowHelp(true)
+	l.SetStatusBarItemName("gist", "gists")
+	l.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{browseKeyMap.openBrowser, browseKeyMap.copyURL, browseKeyMap.dumpStdout}
+	}
+	l.AdditionalFullHelpKeys = l.AdditionalShortHelpKeys
+
+	return &browseModel{
+		client:       client,
+		host:         host,
+		opts:         opts,
+		list:         l,
+		previewCache: map[string]string{},
+	}
+}
+
+func (m *browseModel) Init() tea.Cmd {
+	return m.fetchPage(1)
+}
+
+// fetchPage requests a single page of gistsPerPage gists, rather than
+// re-fetching everything seen so far with a larger limit, so scrolling to
+// the bottom costs one page instead of progressively more of the list.
+func (m *browseModel) fetchPage(page int) tea.Cmd {
+	return func() tea.Msg {
+		gists, err := gistlist.ListGists(m.client, m.host, page, gistsPerPage, "all")
+		return gistsLoadedMsg{page: page, gists: gists, err: err}
+	}
+}
+
+func (m *browseModel) fetchPreview(id string) tea.Cmd {
+	return func() tea.Msg {
+		gist, err := getGist(m.opts, m.client, m.host, id)
+		if err != nil {
+			return previewLoadedMsg{id: id, err: err}
+		}
+		var b strings.Builder
+		for _, fn := range sortedFilenames(gist) {
+			fmt.Fprintf(&b, "%s\n\n%s\n", fn, gist.Files[fn].Content)
+		}
+		return previewLoadedMsg{id: id, content: b.String()}
+	}
+}
+
+func (m *browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		listWidth := msg.Width / 2
+		m.list.SetSize(listWidth, msg.Height-2)
+		m.preview = viewport.New(msg.Width-listWidth, msg.Height-2)
+		m.ready = true
+		return m, nil
+
+	case gistsLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, tea.Quit
+		}
+		newItems := make([]list.Item, len(msg.gists))
+		for i, g := range msg.gists {
+			newItems[i] = gistItem{gist: g}
+		}
+		items := m.list.Items()
+		if msg.page == 1 {
+			items = newItems
+		} else {
+			items = append(items, newItems...)
+		}
+		m.list.SetItems(items)
+		m.page = msg.page
+		m.loadMore = len(msg.gists) >= gistsPerPage
+		return m, nil
+
+	case previewLoadedMsg:
+		if msg.err == nil {
+			m.previewCache[msg.id] = msg.content
+			if msg.id == m.previewFor {
+				m.preview.SetContent(msg.content)
+				m.preview.GotoTop()
+			}
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "enter":
+			if it, ok := m.list.SelectedItem().(gistItem); ok {
+				m.selected = &it.gist
+				m.action = actionNone
+				return m, tea.Quit
+			}
+		}
+
+		switch {
+		case key.Matches(msg, browseKeyMap.openBrowser):
+			if it, ok := m.list.SelectedItem().(gistItem); ok {
+				m.selected = &it.gist
+				m.action = actionOpenBrowser
+				return m, tea.Quit
+			}
+		case key.Matches(msg, browseKeyMap.copyURL):
+			if it, ok := m.list.SelectedItem().(gistItem); ok {
+				m.selected = &it.gist
+				m.action = actionCopyURL
+				return m, tea.Quit
+			}
+		case key.Matches(msg, browseKeyMap.dumpStdout):
+			if it, ok := m.list.SelectedItem().(gistItem); ok {
+				m.selected = &it.gist
+				m.action = actionDumpStdout
+				return m, tea.Quit
+			}
+		}
+	}
+
+	var cmds []tea.Cmd
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	cmds = append(cmds, cmd)
+
+	if it, ok := m.list.SelectedItem().(gistItem); ok && it.gist.ID != m.previewFor {
+		m.previewFor = it.gist.ID
+		if content, cached := m.previewCache[it.gist.ID]; cached {
+			m.preview.SetContent(content)
+			m.preview.GotoTop()
+		} else {
+			cmds = append(cmds, m.fetchPreview(it.gist.ID))
+		}
+	}
+
+	if m.loadMore && m.list.Paginator.OnLastPage() {
+		m.loadMore = false
+		cmds = append(cmds, m.fetchPage(m.page+1))
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+func (m *browseModel) View() string {
+	if !m.ready {
+		return "loading gists…"
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, m.list.View(), m.preview.View())
+}
+
+func sortedFilenames(gist *shared.Gist) []string {
+	filenames := make([]string, 0, len(gist.Files))
+	for fn := range gist.Files {
+		filenames = append(filenames, fn)
+	}
+	sort.Strings(filenames)
+	return filenames
+}
+
+// firstNonGenericFilename returns the first (by sorted name) filename that
+// isn't one of GitHub's auto-generated "gistfileN" placeholders, falling
+// back to the gist ID if every file is named that way. It's used wherever
+// a gist with no description needs a stand-in title.
+func firstNonGenericFilename(gist *shared.Gist) string {
+	for _, fn := range sortedFilenames(gist) {
+		if !strings.HasPrefix(fn, "gistfile") {
+			return fn
+		}
+	}
+	return gist.ID
+}
@@ -0,0 +1,95 @@
+package view
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cli/cli/pkg/cmd/gist/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGistItem(t *testing.T) {
+	withDescription := gistItem{gist: shared.Gist{
+		ID:          "abc123",
+		Description: "my gist",
+		UpdatedAt:   time.Now().Add(-time.Hour),
+		Files:       map[string]*shared.GistFile{"a.go": {}},
+	}}
+	assert.Equal(t, "my gist", withDescription.Title())
+	assert.Contains(t, withDescription.FilterValue(), "my gist")
+	assert.Contains(t, withDescription.FilterValue(), "a.go")
+
+	withoutDescription := gistItem{gist: shared.Gist{
+		ID:    "def456",
+		Files: map[string]*shared.GistFile{"only.go": {}},
+	}}
+	assert.Equal(t, "only.go", withoutDescription.Title())
+}
+
+func TestFirstNonGenericFilename(t *testing.T) {
+	assert.Equal(t, "main.go", firstNonGenericFilename(&shared.Gist{
+		ID:    "abc123",
+		Files: map[string]*shared.GistFile{"gistfile1": {}, "main.go": {}},
+	}))
+
+	// Deterministic regardless of map iteration order: picks the same
+	// (sorted) name every time rather than whatever the map happens to
+	// yield first.
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, "a.go", firstNonGenericFilename(&shared.Gist{
+			Files: map[string]*shared.GistFile{"z.go": {}, "a.go": {}, "m.go": {}},
+		}))
+	}
+
+	assert.Equal(t, "abc123", firstNonGenericFilename(&shared.Gist{
+		ID:    "abc123",
+		Files: map[string]*shared.GistFile{"gistfile1": {}, "gistfile2": {}},
+	}))
+}
+
+func TestSortedFilenames(t *testing.T) {
+	gist := &shared.Gist{Files: map[string]*shared.GistFile{
+		"z.go": {}, "a.go": {}, "m.go": {},
+	}}
+	assert.Equal(t, []string{"a.go", "m.go", "z.go"}, sortedFilenames(gist))
+}
+
+func TestBrowseModel_fetchPageReplacesFirstPageAndAppendsLater(t *testing.T) {
+	m := newBrowseModel(nil, "github.com", &ViewOptions{})
+
+	newModel, _ := m.Update(gistsLoadedMsg{page: 1, gists: []shared.Gist{{ID: "1"}, {ID: "2"}}})
+	m = newModel.(*browseModel)
+	assert.Len(t, m.list.Items(), 2)
+	assert.False(t, m.loadMore)
+
+	newModel, _ = m.Update(gistsLoadedMsg{page: 2, gists: []shared.Gist{{ID: "3"}}})
+	m = newModel.(*browseModel)
+	assert.Len(t, m.list.Items(), 3)
+}
+
+func TestBrowseModel_loadMoreReflectsFullPage(t *testing.T) {
+	m := newBrowseModel(nil, "github.com", &ViewOptions{})
+
+	full := make([]shared.Gist, gistsPerPage)
+	for i := range full {
+		full[i] = shared.Gist{ID: string(rune('a' + i%26))}
+	}
+	newModel, _ := m.Update(gistsLoadedMsg{page: 1, gists: full})
+	m = newModel.(*browseModel)
+	assert.True(t, m.loadMore)
+
+	newModel, _ = m.Update(gistsLoadedMsg{page: 2, gists: []shared.Gist{{ID: "last"}}})
+	m = newModel.(*browseModel)
+	assert.False(t, m.loadMore)
+}
+
+func TestNewBrowseModel_registersExtraHelpKeys(t *testing.T) {
+	m := newBrowseModel(nil, "github.com", &ViewOptions{})
+
+	var keys []string
+	for _, b := range m.list.AdditionalShortHelpKeys() {
+		keys = append(keys, b.Help().Key)
+	}
+	assert.ElementsMatch(t, []string{"o", "y", "p"}, keys)
+	assert.Len(t, m.list.AdditionalFullHelpKeys(), 3)
+}
@@ -2,12 +2,16 @@ package view
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/cli/cli/git"
 	"github.com/cli/cli/internal/ghinstance"
 	"github.com/cli/cli/pkg/cmd/gist/list"
 	"github.com/cli/cli/pkg/cmd/gist/shared"
@@ -28,6 +32,12 @@ type ViewOptions struct {
 	Raw       bool
 	Web       bool
 	ListFiles bool
+	NoColor   bool
+	Output    string
+	Clone     bool
+	NoCache   bool
+
+	Exporter cmdutil.Exporter
 }
 
 func NewCmdView(f *cmdutil.Factory, runF func(*ViewOptions) error) *cobra.Command {
@@ -52,6 +62,10 @@ With no argument, most recent 10 gists will prompt`,
 				opts.Raw = true
 			}
 
+			if opts.Output != "" && opts.Clone {
+				return cmdutil.FlagErrorf("specify only one of `--output` or `--clone`")
+			}
+
 			if runF != nil {
 				return runF(opts)
 			}
@@ -63,10 +77,28 @@ With no argument, most recent 10 gists will prompt`,
 	cmd.Flags().BoolVarP(&opts.Web, "web", "w", false, "Open gist in the browser")
 	cmd.Flags().BoolVarP(&opts.ListFiles, "files", "", false, "List file names from the gist")
 	cmd.Flags().StringVarP(&opts.Filename, "filename", "f", "", "Display a single file from the gist")
+	cmd.Flags().BoolVar(&opts.NoColor, "no-color", false, "Disable syntax highlighting")
+	cmd.Flags().StringVarP(&opts.Output, "output", "O", "", "Save gist files into `directory` (`-` for stdout)")
+	cmd.Flags().BoolVar(&opts.Clone, "clone", false, "Clone the gist as a git repository")
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "Bypass the local gist cache and always fetch fresh data")
+
+	cmdutil.AddJSONFlags(cmd, &opts.Exporter, gistFields)
 
 	return cmd
 }
 
+// gistFields are the field names accepted by `--json` and exposed on the
+// exported gist via exportableGist. "files" expands to
+// filename/language/type/size/content/truncated per file.
+var gistFields = []string{
+	"id",
+	"description",
+	"public",
+	"owner",
+	"updated_at",
+	"files",
+}
+
 func viewRun(opts *ViewOptions) error {
 	gistID := opts.Selector
 	client, err := opts.HttpClient()
@@ -75,10 +107,14 @@ func viewRun(opts *ViewOptions) error {
 	}
 
 	if gistID == "" {
-		gistID, err = promptGists(client)
+		var handled bool
+		gistID, handled, err = promptGists(client, opts)
 		if err != nil {
 			return err
 		}
+		if handled {
+			return nil
+		}
 	}
 
 	if opts.Web {
@@ -101,11 +137,23 @@ func viewRun(opts *ViewOptions) error {
 		gistID = id
 	}
 
-	gist, err := shared.GetGist(client, ghinstance.OverridableDefault(), gistID)
+	gist, err := getGist(opts, client, ghinstance.OverridableDefault(), gistID)
 	if err != nil {
 		return err
 	}
 
+	if opts.Exporter != nil {
+		return opts.Exporter.Export(opts.IO, &exportableGist{gist: gist})
+	}
+
+	if opts.Clone {
+		return cloneGist(opts, gist)
+	}
+
+	if opts.Output != "" {
+		return saveGistFiles(opts, gist)
+	}
+
 	theme := opts.IO.DetectTerminalTheme()
 	markdownStyle := markdown.GetStyle(theme)
 	if err := opts.IO.StartPager(); err != nil {
@@ -113,7 +161,9 @@ func viewRun(opts *ViewOptions) error {
 	}
 	defer opts.IO.StopPager()
 
-	render := func(gf *shared.GistFile) error {
+	colorize := !opts.Raw && !opts.NoColor && opts.IO.ColorEnabled()
+
+	render := func(fn string, gf *shared.GistFile) error {
 		if strings.Contains(gf.Type, "markdown") && !opts.Raw {
 			rendered, err := markdown.Render(gf.Content, markdownStyle, "")
 			if err != nil {
@@ -123,6 +173,19 @@ func viewRun(opts *ViewOptions) error {
 			return err
 		}
 
+		if colorize {
+			ok, err := highlightSource(opts.IO.Out, fn, gf, theme == "dark")
+			if err != nil {
+				return err
+			}
+			if ok {
+				if !strings.HasSuffix(gf.Content, "\n") {
+					fmt.Fprint(opts.IO.Out, "\n")
+				}
+				return nil
+			}
+		}
+
 		if _, err := fmt.Fprint(opts.IO.Out, gf.Content); err != nil {
 			return err
 		}
@@ -138,7 +201,7 @@ func viewRun(opts *ViewOptions) error {
 		if !ok {
 			return fmt.Errorf("gist has no such file: %q", opts.Filename)
 		}
-		return render(gistFile)
+		return render(opts.Filename, gistFile)
 	}
 
 	cs := opts.IO.ColorScheme()
@@ -165,7 +228,7 @@ func viewRun(opts *ViewOptions) error {
 		if showFilenames {
 			fmt.Fprintf(opts.IO.Out, "%s\n\n", cs.Gray(fn))
 		}
-		if err := render(gist.Files[fn]); err != nil {
+		if err := render(fn, gist.Files[fn]); err != nil {
 			return err
 		}
 		if i < len(filenames)-1 {
@@ -176,8 +239,99 @@ func viewRun(opts *ViewOptions) error {
 	return nil
 }
 
-func promptGists(client *http.Client) (gistID string, err error) {
-	gists, err := list.ListGists(client, ghinstance.OverridableDefault(), 10, "all")
+// saveGistFiles writes the gist's files to disk under opts.Output,
+// preserving filenames, or dumps their raw content to stdout when
+// opts.Output is "-". When opts.Filename is set, only that file is saved.
+func saveGistFiles(opts *ViewOptions, gist *shared.Gist) error {
+	var filenames []string
+	if opts.Filename != "" {
+		filenames = []string{opts.Filename}
+	} else {
+		for fn := range gist.Files {
+			filenames = append(filenames, fn)
+		}
+		sort.Strings(filenames)
+	}
+
+	if opts.Output == "-" {
+		for _, fn := range filenames {
+			gf, ok := gist.Files[fn]
+			if !ok {
+				return fmt.Errorf("gist has no such file: %q", fn)
+			}
+			if _, err := fmt.Fprint(opts.IO.Out, gf.Content); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := os.MkdirAll(opts.Output, 0755); err != nil {
+		return err
+	}
+
+	for _, fn := range filenames {
+		gf, ok := gist.Files[fn]
+		if !ok {
+			return fmt.Errorf("gist has no such file: %q", fn)
+		}
+		path := filepath.Join(opts.Output, fn)
+		if err := ioutil.WriteFile(path, []byte(gf.Content), 0644); err != nil {
+			return err
+		}
+		if opts.IO.IsStderrTTY() {
+			fmt.Fprintf(opts.IO.ErrOut, "Saved %s\n", path)
+		}
+	}
+
+	return nil
+}
+
+// cloneGist clones the gist's git repository into a directory named after
+// its description, falling back to the gist ID when there is none. The
+// clone URL is derived from the gist ID and host the same way `gh gist
+// clone` builds it, since shared.Gist carries no git remote URL of its own.
+func cloneGist(opts *ViewOptions, gist *shared.Gist) error {
+	hostname := ghinstance.OverridableDefault()
+	cloneURL := ghinstance.GistPrefix(hostname) + gist.ID + ".git"
+
+	dir := gistCloneDirName(gist)
+	if opts.IO.IsStderrTTY() {
+		fmt.Fprintf(opts.IO.ErrOut, "Cloning into %q...\n", dir)
+	}
+	_, err := git.Clone(cloneURL, []string{dir})
+	return err
+}
+
+func gistCloneDirName(gist *shared.Gist) string {
+	dir := text.ReplaceExcessiveWhitespace(strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' {
+			return '-'
+		}
+		return r
+	}, gist.Description))
+	if dir == "" {
+		return gist.ID
+	}
+	return dir
+}
+
+// promptGists selects a gist when none was given on the command line. It
+// launches the full-screen browser when stdout is a TTY and the user
+// hasn't opted out, falling back to the plain survey.Select prompt
+// otherwise. handled reports whether the browser already carried out the
+// requested action (open in browser, copy URL, dump to stdout) or the user
+// quit it without picking a gist, so viewRun has nothing left to render.
+func promptGists(client *http.Client, opts *ViewOptions) (gistID string, handled bool, err error) {
+	if !opts.IO.IsStdoutTTY() || os.Getenv("GH_PROMPT_DISABLED") != "" {
+		gistID, err = promptGistsSurvey(client)
+		return
+	}
+	return browseGists(client, opts)
+}
+
+func promptGistsSurvey(client *http.Client) (gistID string, err error) {
+	gists, err := list.ListGists(client, ghinstance.OverridableDefault(), 1, 10, "all")
 	if err != nil {
 		return "", err
 	}
@@ -190,12 +344,7 @@ func promptGists(client *http.Client) (gistID string, err error) {
 		gistIDs[i] = gist.ID
 		description := gist.Description
 		if description == "" {
-			for filename := range gist.Files {
-				if !strings.HasPrefix(filename, "gistfile") {
-					description = filename
-					break
-				}
-			}
+			description = firstNonGenericFilename(&gist)
 		}
 		gistTime := utils.FuzzyAgo(time.Since(gist.UpdatedAt))
 		opts = append(opts, fmt.Sprintf("%s (%s)", text.ReplaceExcessiveWhitespace(description), gistTime))
@@ -0,0 +1,76 @@
+package view
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/cli/cli/pkg/cmd/gist/shared"
+	"github.com/cli/cli/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGist() *shared.Gist {
+	return &shared.Gist{
+		ID:          "abc123",
+		Description: "demo",
+		Files: map[string]*shared.GistFile{
+			"one.txt": {Filename: "one.txt", Content: "one\n"},
+			"two.txt": {Filename: "two.txt", Content: "two\n"},
+		},
+	}
+}
+
+func TestSaveGistFiles_toDirectory(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	dir := t.TempDir()
+	opts := &ViewOptions{IO: ios, Output: dir}
+
+	err := saveGistFiles(opts, newTestGist())
+	require.NoError(t, err)
+
+	for _, fn := range []string{"one.txt", "two.txt"} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, fn))
+		require.NoError(t, err)
+		assert.NotEmpty(t, b)
+	}
+}
+
+func TestSaveGistFiles_singleFilename(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	dir := t.TempDir()
+	opts := &ViewOptions{IO: ios, Output: dir, Filename: "one.txt"}
+
+	err := saveGistFiles(opts, newTestGist())
+	require.NoError(t, err)
+
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "one.txt", entries[0].Name())
+}
+
+func TestSaveGistFiles_toStdout(t *testing.T) {
+	ios, _, stdout, _ := iostreams.Test()
+	opts := &ViewOptions{IO: ios, Output: "-"}
+
+	err := saveGistFiles(opts, newTestGist())
+	require.NoError(t, err)
+	assert.Equal(t, "one\ntwo\n", stdout.String())
+}
+
+func TestSaveGistFiles_unknownFilename(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	opts := &ViewOptions{IO: ios, Output: t.TempDir(), Filename: "missing.txt"}
+
+	err := saveGistFiles(opts, newTestGist())
+	assert.EqualError(t, err, `gist has no such file: "missing.txt"`)
+}
+
+func TestGistCloneDirName(t *testing.T) {
+	assert.Equal(t, "abc123", gistCloneDirName(&shared.Gist{ID: "abc123"}))
+	assert.Equal(t, "my-gist", gistCloneDirName(&shared.Gist{ID: "abc123", Description: "my/gist"}))
+	assert.Equal(t, "a b", gistCloneDirName(&shared.Gist{ID: "abc123", Description: "a   b"}))
+	assert.Equal(t, "abc123", gistCloneDirName(&shared.Gist{ID: "abc123", Description: "   "}))
+}